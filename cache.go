@@ -1,9 +1,15 @@
 package go_cache
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"sync"
 	"time"
+
+	"github.com/GuilhermeNogueira/go_cache/policy"
+	"github.com/GuilhermeNogueira/go_cache/store"
+	"github.com/GuilhermeNogueira/go_cache/store/memstore"
 )
 
 // PriceService is a service that we can use to get prices for the items
@@ -12,124 +18,318 @@ type PriceService interface {
 	GetPriceFor(itemCode string) (float64, error)
 }
 
+// PriceServiceContext is an optional extension of PriceService for
+// implementations that can honor cancellation. When actualPriceService
+// implements it, its GetPriceForContext is used instead of GetPriceFor so
+// that a canceled context can abort the upstream call itself.
+type PriceServiceContext interface {
+	GetPriceForContext(ctx context.Context, itemCode string) (float64, error)
+}
+
 // TransparentCache is a cache that wraps the actual service
 // The cache will remember prices we ask for, so that we don't have to wait on every call
 // Cache should only return a price if it is not older than "maxAge", so that we don't get stale prices
 type TransparentCache struct {
 	actualPriceService PriceService
 	maxAge             time.Duration
-	prices             map[string]ItemPriceCache
+	capacity           int
+	maxConcurrency     int
+	policy             policy.Policy
+
+	mu        sync.RWMutex
+	store     store.Store
+	inFlight  map[string]*call
+	onEvicted func(key string, price float64)
+
+	stats      cacheStats
+	prometheus *prometheusMetrics
+
+	stop     chan struct{}
+	stopOnce sync.Once
 }
 
-//ItemPriceCache wraps an cached item with the price and it`s expiration.
-type ItemPriceCache struct {
-	Price      float64
-	Expiration int64
+// call represents an in-flight request for a single item code, shared by
+// every concurrent caller asking for that same code so that only one of
+// them actually hits the PriceService (request coalescing, akin to
+// golang.org/x/sync/singleflight).
+type call struct {
+	wg    sync.WaitGroup
+	price float64
+	err   error
 }
 
+// Option configures a TransparentCache at construction time.
+type Option func(*TransparentCache)
+
+// WithCapacity bounds the cache to at most n entries. Once exceeded, the
+// configured Policy (see WithPolicy) is asked to evict entries until the
+// cache is back within bounds.
+func WithCapacity(n int) Option {
+	return func(c *TransparentCache) {
+		c.capacity = n
+	}
+}
+
+// WithPolicy sets the eviction policy used once the cache is over capacity.
+// Without a capacity set, the policy is tracked but never asked to evict.
+func WithPolicy(p policy.Policy) Option {
+	return func(c *TransparentCache) {
+		c.policy = p
+	}
+}
+
+// WithMaxConcurrency bounds how many item codes GetPricesFor will fetch from
+// the PriceService at once, instead of spawning one goroutine per code.
+func WithMaxConcurrency(n int) Option {
+	return func(c *TransparentCache) {
+		c.maxConcurrency = n
+	}
+}
+
+// WithStore sets the storage backend prices are read from and written to.
+// Defaults to memstore.New(), an in-memory map. Use store/redisstore to
+// share a price cache across multiple instances.
+func WithStore(s store.Store) Option {
+	return func(c *TransparentCache) {
+		c.store = s
+	}
+}
+
+// ItemPriceCache wraps a cached item with its price and expiration.
+type ItemPriceCache = store.ItemPriceCache
+
 // ItemPrice is a temporary holders that contains the code and price of an item
 type ItemPrice struct {
 	code  string
 	price float64
 }
 
-// return true when expired.
-func (item *ItemPriceCache) IsExpired() bool {
-	return time.Now().UnixNano() > item.Expiration
-}
-
 //getExpiration return when an item will be expired.
 func (c *TransparentCache) getExpiration() int64 {
 	return time.Now().Add(c.maxAge).UnixNano()
 }
 
-func NewTransparentCache(actualPriceService PriceService, maxAge time.Duration) *TransparentCache {
-	return &TransparentCache{
+func NewTransparentCache(actualPriceService PriceService, maxAge time.Duration, opts ...Option) *TransparentCache {
+	c := &TransparentCache{
 		actualPriceService: actualPriceService,
 		maxAge:             maxAge,
-		prices:             map[string]ItemPriceCache{},
+		store:              memstore.New(),
+		inFlight:           map[string]*call{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// evictIfNeeded asks the configured policy to evict entries until the cache
+// is back within its configured capacity, returning what was evicted so the
+// caller can invoke OnEvicted once the lock is released. It is a no-op
+// without both a capacity and a policy set.
+func (c *TransparentCache) evictIfNeeded() []ItemPrice {
+	if c.capacity <= 0 || c.policy == nil {
+		return nil
 	}
+
+	var evicted []ItemPrice
+	for c.store.Len() > c.capacity {
+		key, ok := c.policy.Evict()
+		if !ok {
+			break
+		}
+		item, _ := c.store.Get(key)
+		evicted = append(evicted, ItemPrice{key, item.Price})
+		c.store.Delete(key)
+	}
+	return evicted
 }
 
 // GetPriceFor gets the price for the item, either from the cache or the actual service if it was not cached or too old
 func (c *TransparentCache) GetPriceFor(itemCode string) (float64, error) {
-	item, ok := c.prices[itemCode]
+	return c.getOrFetch(context.Background(), itemCode)
+}
 
-	if ok && !item.IsExpired() {
+// getOrFetch returns the cached price for itemCode if present and fresh,
+// otherwise fetches it from the actual service. Concurrent callers asking
+// for the same missing itemCode share a single in-flight request instead of
+// each hitting the service (request coalescing). ctx is only honored before
+// a fetch starts and, when actualPriceService implements
+// PriceServiceContext, for the fetch itself; it has no effect on a fetch
+// already started by another caller that this call ends up waiting on.
+func (c *TransparentCache) getOrFetch(ctx context.Context, itemCode string) (float64, error) {
+	c.mu.Lock()
+
+	if item, ok := c.store.Get(itemCode); ok && !item.IsExpired() {
+		if c.policy != nil {
+			c.policy.Get(itemCode)
+		}
+		c.mu.Unlock()
+		c.stats.recordHit()
+		if c.prometheus != nil {
+			c.prometheus.hits.Inc()
+		}
 		return item.Price, nil
 	}
 
-	log.Printf("fetching item [ %v ] price from external service", itemCode)
-	price, err := c.actualPriceService.GetPriceFor(itemCode)
-
-	if err != nil {
-		return 0, fmt.Errorf("getting item from service : %v", err.Error())
+	if inFlight, ok := c.inFlight[itemCode]; ok {
+		c.mu.Unlock()
+		c.stats.recordInFlightCoalesced()
+		inFlight.wg.Wait()
+		return inFlight.price, inFlight.err
 	}
 
-	c.prices[itemCode] = ItemPriceCache{
-		Price:      price,
-		Expiration: c.getExpiration(),
+	if err := ctx.Err(); err != nil {
+		c.mu.Unlock()
+		return 0, err
 	}
 
-	return price, nil
-}
+	thisCall := &call{}
+	thisCall.wg.Add(1)
+	c.inFlight[itemCode] = thisCall
+	c.mu.Unlock()
 
-// GetPricesFor gets the prices for several items at once, some might be found in the cache, others might not
-// If any of the operations returns an error, it should return an error as well
-func (c *TransparentCache) GetPricesFor(itemCodes ...string) ([]float64, error) {
+	c.stats.recordMiss()
+	if c.prometheus != nil {
+		c.prometheus.misses.Inc()
+	}
 
-	var results []float64
+	log.Printf("fetching item [ %v ] price from external service", itemCode)
+	fetchStart := time.Now()
+	price, err := c.fetchFromService(ctx, itemCode)
+	latency := time.Since(fetchStart)
 
-	var ch = make(chan ItemPrice)
+	c.stats.recordUpstreamLatency(latency)
+	if c.prometheus != nil {
+		c.prometheus.upstreamLatency.Observe(latency.Seconds())
+	}
 
-	var errCh = make(chan error)
+	if err != nil {
+		thisCall.err = fmt.Errorf("getting item from service : %v", err.Error())
+		c.stats.recordError()
+		if c.prometheus != nil {
+			c.prometheus.errors.Inc()
+		}
+	} else {
+		thisCall.price = price
+	}
 
-	defer close(ch)
-	defer close(errCh)
+	c.mu.Lock()
+	delete(c.inFlight, itemCode)
+	var evicted []ItemPrice
+	if thisCall.err == nil {
+		c.store.Set(itemCode, ItemPriceCache{
+			Price:      price,
+			Expiration: c.getExpiration(),
+		})
+		if c.policy != nil {
+			c.policy.Add(itemCode)
+		}
+		evicted = c.evictIfNeeded()
+	}
+	onEvicted := c.onEvicted
+	c.mu.Unlock()
 
-	for _, itemCode := range itemCodes {
-		go func(code string) {
+	thisCall.wg.Done()
 
-			item, ok := c.prices[code]
+	if len(evicted) > 0 {
+		c.stats.recordEvictions(len(evicted))
+		if c.prometheus != nil {
+			c.prometheus.evictions.Add(float64(len(evicted)))
+		}
+	}
+	if onEvicted != nil {
+		for _, e := range evicted {
+			onEvicted(e.code, e.price)
+		}
+	}
 
-			if ok && !item.IsExpired() {
-				ch <- ItemPrice{code, item.Price}
-				return
-			}
+	return thisCall.price, thisCall.err
+}
 
-			log.Printf("fetching item [ %v ] price from external service", code)
-			price, err := c.actualPriceService.GetPriceFor(code)
+// fetchFromService calls the upstream PriceService, preferring
+// GetPriceForContext when actualPriceService implements PriceServiceContext
+// so that a canceled ctx can abort the call itself.
+func (c *TransparentCache) fetchFromService(ctx context.Context, itemCode string) (float64, error) {
+	if svc, ok := c.actualPriceService.(PriceServiceContext); ok {
+		return svc.GetPriceForContext(ctx, itemCode)
+	}
+	return c.actualPriceService.GetPriceFor(itemCode)
+}
 
-			if err != nil {
-				errCh <- err
-			}
+// GetPricesFor gets the prices for several items at once, some might be found in the cache, others might not
+// If any of the operations returns an error, it should return an error as well
+func (c *TransparentCache) GetPricesFor(itemCodes ...string) ([]float64, error) {
+	return c.GetPricesForContext(context.Background(), itemCodes...)
+}
 
-			ch <- ItemPrice{code, price}
+// concurrencyLimit returns how many item codes GetPricesFor may fetch at
+// once, honoring WithMaxConcurrency if it was set to a smaller value.
+func (c *TransparentCache) concurrencyLimit(n int) int {
+	if n == 0 {
+		return 1
+	}
+	if c.maxConcurrency > 0 && c.maxConcurrency < n {
+		return c.maxConcurrency
+	}
+	return n
+}
 
-		}(itemCode)
+// Delete removes itemCode from the cache, if present.
+func (c *TransparentCache) Delete(itemCode string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
+	c.store.Delete(itemCode)
+	if c.policy != nil {
+		c.policy.Remove(itemCode)
 	}
+}
 
-	for {
-		select {
-		case err := <-errCh:
-			log.Printf("operation cancelled due error %v", err)
-			return nil, err
-		case result := <-ch:
+// Flush removes every entry from the cache.
+func (c *TransparentCache) Flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-			code := result.code
-			price := result.price
+	for _, key := range c.store.Keys() {
+		if c.policy != nil {
+			c.policy.Remove(key)
+		}
+		c.store.Delete(key)
+	}
+}
 
-			results = append(results, price)
+// ItemCount returns the number of entries currently held by the cache,
+// including any that have expired but have not yet been cleaned up.
+func (c *TransparentCache) ItemCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 
-			c.prices[code] = ItemPriceCache{
-				Price:      price,
-				Expiration: c.getExpiration(),
-			}
+	return c.store.Len()
+}
 
-			if len(results) == len(itemCodes) {
-				return results, nil
-			}
+// Items returns a copy of the non-expired entries in the cache. Because it
+// is a copy, it is safe to range over without any additional locking.
+func (c *TransparentCache) Items() map[string]ItemPriceCache {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	items := make(map[string]ItemPriceCache)
+	for _, key := range c.store.Keys() {
+		if item, ok := c.store.Get(key); ok && !item.IsExpired() {
+			items[key] = item
 		}
 	}
+	return items
+}
+
+// OnEvicted sets a callback invoked whenever an entry is removed from the
+// cache by the janitor (see NewTransparentCacheWithJanitor) or by the
+// eviction policy (see WithPolicy). It is not invoked by Delete or Flush.
+func (c *TransparentCache) OnEvicted(f func(key string, price float64)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.onEvicted = f
 }
@@ -0,0 +1,75 @@
+package go_cache
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/GuilhermeNogueira/go_cache/policy/arc"
+	"github.com/GuilhermeNogueira/go_cache/policy/clock"
+	"github.com/GuilhermeNogueira/go_cache/policy/lfu"
+	"github.com/GuilhermeNogueira/go_cache/policy/lru"
+	"github.com/GuilhermeNogueira/go_cache/policy/simple"
+)
+
+type benchPriceService struct{}
+
+func (benchPriceService) GetPriceFor(itemCode string) (float64, error) {
+	return 1, nil
+}
+
+// benchmarkPolicy drives a cache with a Zipf-like access pattern over more
+// keys than the configured capacity, so the benchmark also reports how many
+// requests each policy turns into cache hits.
+func benchmarkPolicy(b *testing.B, newCache func() *TransparentCache) {
+	const keySpace = 200
+
+	c := newCache()
+	r := rand.New(rand.NewSource(1))
+	zipf := rand.NewZipf(r, 1.1, 1, keySpace-1)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		key := itemCodeFor(zipf.Uint64())
+		_, _ = c.GetPriceFor(key)
+	}
+
+	stats := c.Stats()
+	b.ReportMetric(float64(stats.Hits)/float64(stats.Hits+stats.Misses), "hit-ratio")
+}
+
+func itemCodeFor(n uint64) string {
+	return fmt.Sprintf("item-%d", n)
+}
+
+func BenchmarkPolicySimple(b *testing.B) {
+	benchmarkPolicy(b, func() *TransparentCache {
+		return NewTransparentCache(benchPriceService{}, time.Minute, WithCapacity(50), WithPolicy(simple.New()))
+	})
+}
+
+func BenchmarkPolicyLRU(b *testing.B) {
+	benchmarkPolicy(b, func() *TransparentCache {
+		return NewTransparentCache(benchPriceService{}, time.Minute, WithCapacity(50), WithPolicy(lru.New(50)))
+	})
+}
+
+func BenchmarkPolicyLFU(b *testing.B) {
+	benchmarkPolicy(b, func() *TransparentCache {
+		return NewTransparentCache(benchPriceService{}, time.Minute, WithCapacity(50), WithPolicy(lfu.New(50)))
+	})
+}
+
+func BenchmarkPolicyClock(b *testing.B) {
+	benchmarkPolicy(b, func() *TransparentCache {
+		return NewTransparentCache(benchPriceService{}, time.Minute, WithCapacity(50), WithPolicy(clock.New(50)))
+	})
+}
+
+func BenchmarkPolicyARC(b *testing.B) {
+	benchmarkPolicy(b, func() *TransparentCache {
+		return NewTransparentCache(benchPriceService{}, time.Minute, WithCapacity(50), WithPolicy(arc.New(50)))
+	})
+}
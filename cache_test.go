@@ -0,0 +1,177 @@
+package go_cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type countingPriceService struct {
+	calls int64
+	delay time.Duration
+	err   error
+}
+
+func (s *countingPriceService) GetPriceFor(itemCode string) (float64, error) {
+	atomic.AddInt64(&s.calls, 1)
+	if s.delay > 0 {
+		time.Sleep(s.delay)
+	}
+	if s.err != nil {
+		return 0, s.err
+	}
+	return 1, nil
+}
+
+func TestGetPriceForCachesResult(t *testing.T) {
+	svc := &countingPriceService{}
+	c := NewTransparentCache(svc, time.Minute)
+
+	if _, err := c.GetPriceFor("a"); err != nil {
+		t.Fatalf("GetPriceFor() error = %v", err)
+	}
+	if _, err := c.GetPriceFor("a"); err != nil {
+		t.Fatalf("GetPriceFor() error = %v", err)
+	}
+
+	if calls := atomic.LoadInt64(&svc.calls); calls != 1 {
+		t.Fatalf("PriceService called %d times, want 1", calls)
+	}
+}
+
+func TestGetPriceForRefetchesAfterExpiration(t *testing.T) {
+	svc := &countingPriceService{}
+	c := NewTransparentCache(svc, time.Millisecond)
+
+	if _, err := c.GetPriceFor("a"); err != nil {
+		t.Fatalf("GetPriceFor() error = %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if _, err := c.GetPriceFor("a"); err != nil {
+		t.Fatalf("GetPriceFor() error = %v", err)
+	}
+
+	if calls := atomic.LoadInt64(&svc.calls); calls != 2 {
+		t.Fatalf("PriceService called %d times, want 2", calls)
+	}
+}
+
+func TestConcurrentGetPriceForCoalescesIntoOneFetch(t *testing.T) {
+	svc := &countingPriceService{delay: 20 * time.Millisecond}
+	c := NewTransparentCache(svc, time.Minute)
+
+	const callers = 20
+	var wg sync.WaitGroup
+	wg.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetPriceFor("a"); err != nil {
+				t.Errorf("GetPriceFor() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls := atomic.LoadInt64(&svc.calls); calls != 1 {
+		t.Fatalf("PriceService called %d times, want 1", calls)
+	}
+	if coalesced := c.Stats().InFlightCoalesced; coalesced != callers-1 {
+		t.Fatalf("Stats().InFlightCoalesced = %d, want %d", coalesced, callers-1)
+	}
+}
+
+func TestGetPriceForPropagatesServiceError(t *testing.T) {
+	wantErr := errors.New("boom")
+	svc := &countingPriceService{err: wantErr}
+	c := NewTransparentCache(svc, time.Minute)
+
+	if _, err := c.GetPriceFor("a"); err == nil {
+		t.Fatal("GetPriceFor() error = nil, want an error")
+	}
+}
+
+func TestGetPricesForPreservesOrder(t *testing.T) {
+	svc := &codePriceService{prices: map[string]float64{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5}}
+	c := NewTransparentCache(svc, time.Minute)
+
+	codes := []string{"a", "b", "c", "d", "e"}
+	prices, err := c.GetPricesFor(codes...)
+	if err != nil {
+		t.Fatalf("GetPricesFor() error = %v", err)
+	}
+	if len(prices) != len(codes) {
+		t.Fatalf("len(prices) = %d, want %d", len(prices), len(codes))
+	}
+
+	want := []float64{1, 2, 3, 4, 5}
+	for i := range want {
+		if prices[i] != want[i] {
+			t.Fatalf("prices[%d] = %v, want %v", i, prices[i], want[i])
+		}
+	}
+}
+
+func TestGetPricesForRespectsMaxConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int64
+	svc := &trackingPriceService{
+		onStart: func() {
+			cur := atomic.AddInt64(&inFlight, 1)
+			for {
+				prev := atomic.LoadInt64(&maxInFlight)
+				if cur <= prev || atomic.CompareAndSwapInt64(&maxInFlight, prev, cur) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+		},
+		onEnd: func() { atomic.AddInt64(&inFlight, -1) },
+	}
+
+	c := NewTransparentCache(svc, time.Minute, WithMaxConcurrency(2))
+
+	codes := make([]string, 10)
+	for i := range codes {
+		codes[i] = string(rune('a' + i))
+	}
+
+	if _, err := c.GetPricesFor(codes...); err != nil {
+		t.Fatalf("GetPricesFor() error = %v", err)
+	}
+
+	if got := atomic.LoadInt64(&maxInFlight); got > 2 {
+		t.Fatalf("max concurrent PriceService calls = %d, want <= 2", got)
+	}
+}
+
+// trackingPriceService calls onStart/onEnd around each fetch so tests can
+// observe how many calls run concurrently.
+type trackingPriceService struct {
+	onStart func()
+	onEnd   func()
+}
+
+func (s *trackingPriceService) GetPriceFor(itemCode string) (float64, error) {
+	s.onStart()
+	defer s.onEnd()
+	return 1, nil
+}
+
+func TestDeleteRemovesEntry(t *testing.T) {
+	svc := &countingPriceService{}
+	c := NewTransparentCache(svc, time.Minute)
+
+	if _, err := c.GetPriceFor("a"); err != nil {
+		t.Fatalf("GetPriceFor() error = %v", err)
+	}
+	c.Delete("a")
+
+	if _, err := c.GetPriceFor("a"); err != nil {
+		t.Fatalf("GetPriceFor() error = %v", err)
+	}
+	if calls := atomic.LoadInt64(&svc.calls); calls != 2 {
+		t.Fatalf("PriceService called %d times, want 2", calls)
+	}
+}
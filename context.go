@@ -0,0 +1,95 @@
+package go_cache
+
+import (
+	"context"
+	"sync"
+)
+
+// GetPricesForContext gets the prices for several items at once, like
+// GetPricesFor, but stops dispatching new fetches as soon as ctx is
+// canceled instead of running to completion. It still waits for any
+// already-dispatched fetches to finish before returning, so no goroutine is
+// left running against actualPriceService after the call returns. Results
+// are returned in the same order as itemCodes, regardless of the order in
+// which the underlying fetches complete.
+func (c *TransparentCache) GetPricesForContext(ctx context.Context, itemCodes ...string) ([]float64, error) {
+	results := make([]float64, len(itemCodes))
+	errs := make([]error, len(itemCodes))
+
+	sem := make(chan struct{}, c.concurrencyLimit(len(itemCodes)))
+	var wg sync.WaitGroup
+
+dispatch:
+	for i, itemCode := range itemCodes {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break dispatch
+		}
+
+		wg.Add(1)
+		go func(i int, code string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results[i], errs[i] = c.getOrFetch(ctx, code)
+		}(i, itemCode)
+	}
+
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// GetPricesForPartial gets the prices for several items at once like
+// GetPricesForContext, but never aborts the whole batch on a single
+// failure: it returns the price for every item code that succeeded and a
+// per-code error for every one that didn't, so callers can act on whatever
+// came back. Canceling ctx stops dispatching new fetches but still returns
+// whatever has completed so far.
+func (c *TransparentCache) GetPricesForPartial(ctx context.Context, itemCodes ...string) (map[string]float64, map[string]error) {
+	prices := make(map[string]float64)
+	errs := make(map[string]error)
+	var mu sync.Mutex
+
+	sem := make(chan struct{}, c.concurrencyLimit(len(itemCodes)))
+	var wg sync.WaitGroup
+
+dispatch:
+	for _, itemCode := range itemCodes {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break dispatch
+		}
+
+		wg.Add(1)
+		go func(code string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			price, err := c.getOrFetch(ctx, code)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[code] = err
+			} else {
+				prices[code] = price
+			}
+		}(itemCode)
+	}
+
+	wg.Wait()
+
+	return prices, errs
+}
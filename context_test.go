@@ -0,0 +1,140 @@
+package go_cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type codePriceService struct {
+	prices map[string]float64
+	errs   map[string]error
+}
+
+func (s *codePriceService) GetPriceFor(itemCode string) (float64, error) {
+	if err, ok := s.errs[itemCode]; ok {
+		return 0, err
+	}
+	return s.prices[itemCode], nil
+}
+
+func TestGetPricesForContextPreservesOrder(t *testing.T) {
+	svc := &codePriceService{prices: map[string]float64{"a": 1, "b": 2, "c": 3}}
+	c := NewTransparentCache(svc, time.Minute)
+
+	codes := []string{"c", "a", "b"}
+	prices, err := c.GetPricesForContext(context.Background(), codes...)
+	if err != nil {
+		t.Fatalf("GetPricesForContext() error = %v", err)
+	}
+
+	want := []float64{3, 1, 2}
+	for i, p := range prices {
+		if p != want[i] {
+			t.Fatalf("prices[%d] = %v, want %v", i, p, want[i])
+		}
+	}
+}
+
+func TestGetPricesForContextReturnsErrorOnAnyFailure(t *testing.T) {
+	wantErr := errors.New("boom")
+	svc := &codePriceService{
+		prices: map[string]float64{"a": 1},
+		errs:   map[string]error{"b": wantErr},
+	}
+	c := NewTransparentCache(svc, time.Minute)
+
+	if _, err := c.GetPricesForContext(context.Background(), "a", "b"); err == nil {
+		t.Fatal("GetPricesForContext() error = nil, want an error")
+	}
+}
+
+func TestGetPricesForContextStopsOnCancellation(t *testing.T) {
+	svc := &trackingPriceService{
+		onStart: func() { time.Sleep(50 * time.Millisecond) },
+		onEnd:   func() {},
+	}
+	c := NewTransparentCache(svc, time.Minute, WithMaxConcurrency(1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	codes := make([]string, 10)
+	for i := range codes {
+		codes[i] = string(rune('a' + i))
+	}
+
+	if _, err := c.GetPricesForContext(ctx, codes...); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetPricesForContext() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestGetPricesForContextWaitsForInFlightFetchesBeforeReturning(t *testing.T) {
+	var inFlight int32
+	svc := &trackingPriceService{
+		onStart: func() {
+			atomic.AddInt32(&inFlight, 1)
+			time.Sleep(50 * time.Millisecond)
+		},
+		onEnd: func() { atomic.AddInt32(&inFlight, -1) },
+	}
+	c := NewTransparentCache(svc, time.Minute, WithMaxConcurrency(5))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	codes := make([]string, 50)
+	for i := range codes {
+		codes[i] = fmt.Sprintf("key-%d", i)
+	}
+
+	if _, err := c.GetPricesForContext(ctx, codes...); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetPricesForContext() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	if got := atomic.LoadInt32(&inFlight); got != 0 {
+		t.Fatalf("%d fetches still in flight after GetPricesForContext returned, want 0", got)
+	}
+}
+
+func TestGetPricesForPartialReturnsSuccessesAndFailuresSeparately(t *testing.T) {
+	wantErr := errors.New("boom")
+	svc := &codePriceService{
+		prices: map[string]float64{"a": 1, "c": 3},
+		errs:   map[string]error{"b": wantErr},
+	}
+	c := NewTransparentCache(svc, time.Minute)
+
+	prices, errs := c.GetPricesForPartial(context.Background(), "a", "b", "c")
+
+	if len(prices) != 2 || prices["a"] != 1 || prices["c"] != 3 {
+		t.Fatalf("prices = %v, want a=1 and c=3", prices)
+	}
+	if len(errs) != 1 || errs["b"] == nil {
+		t.Fatalf("errs = %v, want an entry for %q", errs, "b")
+	}
+}
+
+func TestGetPricesForPartialStopsDispatchingAfterCancellation(t *testing.T) {
+	svc := &trackingPriceService{
+		onStart: func() { time.Sleep(50 * time.Millisecond) },
+		onEnd:   func() {},
+	}
+	c := NewTransparentCache(svc, time.Minute, WithMaxConcurrency(1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	codes := make([]string, 10)
+	for i := range codes {
+		codes[i] = string(rune('a' + i))
+	}
+
+	prices, errs := c.GetPricesForPartial(ctx, codes...)
+	if len(prices)+len(errs) >= len(codes) {
+		t.Fatalf("got %d results, want dispatch to stop short of all %d codes after cancellation", len(prices)+len(errs), len(codes))
+	}
+}
@@ -0,0 +1,75 @@
+package go_cache
+
+import "time"
+
+// NewTransparentCacheWithJanitor creates a TransparentCache identical to
+// NewTransparentCache, but also starts a background janitor goroutine that
+// walks the cache every cleanupInterval and deletes expired entries, in the
+// same spirit as patrickmn/go-cache. Call Stop to terminate the janitor.
+func NewTransparentCacheWithJanitor(actualPriceService PriceService, maxAge, cleanupInterval time.Duration, opts ...Option) *TransparentCache {
+	c := NewTransparentCache(actualPriceService, maxAge, opts...)
+	c.stop = make(chan struct{})
+
+	go c.runJanitor(cleanupInterval)
+
+	return c
+}
+
+// Stop terminates the janitor goroutine started by
+// NewTransparentCacheWithJanitor. It is safe to call more than once, and is
+// a no-op on a cache that was never given a janitor.
+func (c *TransparentCache) Stop() {
+	if c.stop == nil {
+		return
+	}
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+}
+
+func (c *TransparentCache) runJanitor(cleanupInterval time.Duration) {
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.deleteExpired()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// deleteExpired removes every entry whose Expiration has passed, notifying
+// OnEvicted for each one once the lock has been released.
+func (c *TransparentCache) deleteExpired() {
+	c.mu.Lock()
+
+	var expired []ItemPrice
+	for _, key := range c.store.Keys() {
+		item, ok := c.store.Get(key)
+		if ok && item.IsExpired() {
+			expired = append(expired, ItemPrice{key, item.Price})
+			c.store.Delete(key)
+			if c.policy != nil {
+				c.policy.Remove(key)
+			}
+		}
+	}
+	onEvicted := c.onEvicted
+
+	c.mu.Unlock()
+
+	if len(expired) > 0 {
+		c.stats.recordEvictions(len(expired))
+		if c.prometheus != nil {
+			c.prometheus.evictions.Add(float64(len(expired)))
+		}
+	}
+	if onEvicted != nil {
+		for _, e := range expired {
+			onEvicted(e.code, e.price)
+		}
+	}
+}
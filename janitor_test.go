@@ -0,0 +1,96 @@
+package go_cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GuilhermeNogueira/go_cache/policy/lru"
+)
+
+func TestFlushRemovesAllEntriesAndPolicyState(t *testing.T) {
+	svc := &countingPriceService{}
+	c := NewTransparentCache(svc, time.Minute, WithCapacity(2), WithPolicy(lru.New(2)))
+
+	if _, err := c.GetPriceFor("a"); err != nil {
+		t.Fatalf("GetPriceFor() error = %v", err)
+	}
+	if _, err := c.GetPriceFor("b"); err != nil {
+		t.Fatalf("GetPriceFor() error = %v", err)
+	}
+
+	c.Flush()
+
+	if n := c.ItemCount(); n != 0 {
+		t.Fatalf("ItemCount() = %d, want 0", n)
+	}
+
+	var evicted []string
+	c.OnEvicted(func(key string, price float64) {
+		evicted = append(evicted, key)
+	})
+
+	for _, code := range []string{"x", "y", "z"} {
+		if _, err := c.GetPriceFor(code); err != nil {
+			t.Fatalf("GetPriceFor(%q) error = %v", code, err)
+		}
+	}
+
+	if len(evicted) != 1 {
+		t.Fatalf("OnEvicted fired for %v, want exactly one real eviction", evicted)
+	}
+	if got := c.Stats().Evictions; got != 1 {
+		t.Fatalf("Stats().Evictions = %d, want 1 (flushed keys must not be counted)", got)
+	}
+}
+
+func TestItemsExcludesExpiredEntries(t *testing.T) {
+	svc := &countingPriceService{}
+	c := NewTransparentCache(svc, time.Millisecond)
+
+	if _, err := c.GetPriceFor("a"); err != nil {
+		t.Fatalf("GetPriceFor() error = %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	if items := c.Items(); len(items) != 0 {
+		t.Fatalf("Items() = %v, want empty (entry should have expired)", items)
+	}
+}
+
+func TestJanitorDeletesExpiredEntriesAndUpdatesStats(t *testing.T) {
+	svc := &countingPriceService{}
+	c := NewTransparentCacheWithJanitor(svc, time.Millisecond, time.Millisecond)
+	defer c.Stop()
+
+	evicted := make(chan string, 1)
+	c.OnEvicted(func(key string, price float64) {
+		evicted <- key
+	})
+
+	if _, err := c.GetPriceFor("a"); err != nil {
+		t.Fatalf("GetPriceFor() error = %v", err)
+	}
+
+	select {
+	case key := <-evicted:
+		if key != "a" {
+			t.Fatalf("OnEvicted key = %q, want %q", key, "a")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("janitor did not evict the expired entry in time")
+	}
+
+	if got := c.Stats().Evictions; got != 1 {
+		t.Fatalf("Stats().Evictions = %d, want 1", got)
+	}
+}
+
+func TestStopIsIdempotentAndSafeWithoutJanitor(t *testing.T) {
+	svc := &countingPriceService{}
+	c := NewTransparentCache(svc, time.Minute)
+	c.Stop() // no janitor was started, must not panic
+
+	withJanitor := NewTransparentCacheWithJanitor(svc, time.Minute, time.Hour)
+	withJanitor.Stop()
+	withJanitor.Stop() // calling twice must not panic
+}
@@ -0,0 +1,196 @@
+// Package arc implements the Adaptive Replacement Cache policy described by
+// Megiddo and Modha, which adapts between recency (LRU) and frequency (LFU)
+// by tracking ghost entries of recently evicted keys.
+package arc
+
+import "container/list"
+
+// Policy is an Adaptive Replacement Cache: T1/T2 hold the keys currently
+// considered "in cache" (recency/frequency respectively), while B1/B2 are
+// ghost lists of recently evicted keys used to adapt the target size p of T1.
+// Ghost entries are looked up through the ghosts map rather than scanning
+// B1/B2, and trimGhosts keeps |T1|+|B1| and |T2|+|B2| within capacity so B1/B2
+// can't grow without bound on a workload of non-repeating keys.
+type Policy struct {
+	capacity int
+	p        int
+
+	t1, t2, b1, b2 *list.List
+	elements       map[string]*list.Element
+	onT1           map[string]bool
+
+	ghosts map[string]*list.Element
+	onB1   map[string]bool
+}
+
+// New creates an ARC Policy for the given capacity.
+func New(capacity int) *Policy {
+	return &Policy{
+		capacity: capacity,
+		t1:       list.New(),
+		t2:       list.New(),
+		b1:       list.New(),
+		b2:       list.New(),
+		elements: make(map[string]*list.Element),
+		onT1:     make(map[string]bool),
+		ghosts:   make(map[string]*list.Element),
+		onB1:     make(map[string]bool),
+	}
+}
+
+// Add registers a newly inserted key, replaying ARC's case I-IV logic for a
+// cache miss.
+func (p *Policy) Add(key string) {
+	if elem, ok := p.elements[key]; ok {
+		// Already tracked: treat like an access (case I, re-insert into T2).
+		p.promote(key, elem)
+		return
+	}
+
+	if _, ok := p.ghosts[key]; ok {
+		if p.onB1[key] {
+			// Case II: key seen before in B1, grow p in favor of recency.
+			delta := 1
+			if p.b1.Len() < p.b2.Len() {
+				delta = p.b2.Len() / p.b1.Len()
+			}
+			p.p = min(p.p+delta, p.capacity)
+		} else {
+			// Case III: key seen before in B2, shrink p in favor of frequency.
+			delta := 1
+			if p.b2.Len() < p.b1.Len() {
+				delta = p.b1.Len() / p.b2.Len()
+			}
+			p.p = max(p.p-delta, 0)
+		}
+		p.removeGhost(key)
+		p.elements[key] = p.t2.PushFront(key)
+		return
+	}
+
+	// Case IV: brand new key, goes to T1.
+	p.elements[key] = p.t1.PushFront(key)
+	p.onT1[key] = true
+}
+
+func (p *Policy) promote(key string, elem *list.Element) {
+	if p.onT1[key] {
+		p.t1.Remove(elem)
+		delete(p.onT1, key)
+	} else {
+		p.t2.Remove(elem)
+	}
+	p.elements[key] = p.t2.PushFront(key)
+}
+
+// Get promotes key into T2, marking it as frequently used.
+func (p *Policy) Get(key string) {
+	if elem, ok := p.elements[key]; ok {
+		p.promote(key, elem)
+	}
+}
+
+// Evict replaces a key from T1 or T2 following ARC's replacement rule and
+// moves it to the matching ghost list: T1 is favored once it grows past the
+// adaptive target size p, otherwise T2 gives up its least recent key.
+func (p *Policy) Evict() (string, bool) {
+	if p.t1.Len() > 0 && p.t1.Len() > p.p {
+		return p.evictFrom(p.t1, p.b1, true)
+	}
+	if p.t2.Len() > 0 {
+		return p.evictFrom(p.t2, p.b2, false)
+	}
+	if p.t1.Len() > 0 {
+		return p.evictFrom(p.t1, p.b1, true)
+	}
+	return "", false
+}
+
+func (p *Policy) evictFrom(src, ghost *list.List, fromT1 bool) (string, bool) {
+	elem := src.Back()
+	if elem == nil {
+		return "", false
+	}
+	key := elem.Value.(string)
+	src.Remove(elem)
+	delete(p.elements, key)
+	if fromT1 {
+		delete(p.onT1, key)
+	}
+
+	p.ghosts[key] = ghost.PushFront(key)
+	p.onB1[key] = fromT1
+	p.trimGhosts()
+
+	return key, true
+}
+
+// trimGhosts enforces Megiddo and Modha's bound that each ghost list,
+// combined with its paired cache list, never holds more than capacity
+// entries: |T1|+|B1| <= c and |T2|+|B2| <= c. Without it, keys that are
+// evicted and never requested again would accumulate in B1/B2 forever.
+func (p *Policy) trimGhosts() {
+	for p.b1.Len() > 0 && p.t1.Len()+p.b1.Len() > p.capacity {
+		p.dropGhostTail(p.b1)
+	}
+	for p.b2.Len() > 0 && p.t2.Len()+p.b2.Len() > p.capacity {
+		p.dropGhostTail(p.b2)
+	}
+}
+
+func (p *Policy) dropGhostTail(l *list.List) {
+	elem := l.Back()
+	key := elem.Value.(string)
+	l.Remove(elem)
+	delete(p.ghosts, key)
+	delete(p.onB1, key)
+}
+
+// GhostLens returns the current size of the B1 and B2 ghost lists, mainly
+// useful for verifying they stay bounded under trimGhosts.
+func (p *Policy) GhostLens() (b1, b2 int) {
+	return p.b1.Len(), p.b2.Len()
+}
+
+// Remove forgets key, wherever it sits (T1, T2 or the ghost lists).
+func (p *Policy) Remove(key string) {
+	if elem, ok := p.elements[key]; ok {
+		if p.onT1[key] {
+			p.t1.Remove(elem)
+			delete(p.onT1, key)
+		} else {
+			p.t2.Remove(elem)
+		}
+		delete(p.elements, key)
+		return
+	}
+	p.removeGhost(key)
+}
+
+func (p *Policy) removeGhost(key string) {
+	elem, ok := p.ghosts[key]
+	if !ok {
+		return
+	}
+	if p.onB1[key] {
+		p.b1.Remove(elem)
+	} else {
+		p.b2.Remove(elem)
+	}
+	delete(p.ghosts, key)
+	delete(p.onB1, key)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
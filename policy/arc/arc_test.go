@@ -0,0 +1,92 @@
+package arc_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GuilhermeNogueira/go_cache/policy/arc"
+)
+
+func TestEvictsFromT1WhenOverCapacity(t *testing.T) {
+	p := arc.New(2)
+
+	p.Add("a")
+	p.Add("b")
+	p.Add("c") // only ever accessed once each, so all three sit in T1
+
+	key, ok := p.Evict()
+	if !ok || key != "a" {
+		t.Fatalf("Evict() = %q, %v, want %q, true", key, ok, "a")
+	}
+}
+
+func TestGetPromotesToT2AndProtectsFromEviction(t *testing.T) {
+	p := arc.New(2)
+
+	p.Add("a")
+	p.Add("b")
+	p.Get("a") // a moves to T2, b is the only T1 entry left
+
+	key, ok := p.Evict()
+	if !ok || key != "b" {
+		t.Fatalf("Evict() = %q, %v, want %q, true", key, ok, "b")
+	}
+}
+
+func TestReAddingAGhostKeyIsNotTreatedAsBrandNew(t *testing.T) {
+	p := arc.New(1)
+
+	p.Add("a")
+	p.Evict() // a now lives in the B1 ghost list
+
+	p.Add("a") // case II: a was seen in B1
+
+	key, ok := p.Evict()
+	if !ok || key != "a" {
+		t.Fatalf("Evict() = %q, %v, want %q, true", key, ok, "a")
+	}
+
+	// a was re-inserted into T2, so evicting it again lands it in B2, not B1
+	if b1, b2 := p.GhostLens(); b1 != 0 || b2 != 1 {
+		t.Fatalf("GhostLens() = %d, %d, want 0, 1", b1, b2)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	p := arc.New(2)
+	p.Add("a")
+	p.Add("b")
+
+	p.Remove("a")
+
+	key, ok := p.Evict()
+	if !ok || key != "b" {
+		t.Fatalf("Evict() = %q, %v, want %q, true", key, ok, "b")
+	}
+	if _, ok := p.Evict(); ok {
+		t.Fatalf("Evict() ok = true on empty policy, want false")
+	}
+}
+
+func TestEvictOnEmptyPolicy(t *testing.T) {
+	p := arc.New(2)
+
+	if _, ok := p.Evict(); ok {
+		t.Fatalf("Evict() ok = true on empty policy, want false")
+	}
+}
+
+func TestGhostListsStayBoundedUnderNonRepeatingKeys(t *testing.T) {
+	p := arc.New(100)
+
+	for i := 0; i < 5000; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		p.Add(key)
+		p.Evict()
+	}
+
+	b1, b2 := p.GhostLens()
+	if b1+b2 > 200 {
+		t.Fatalf("ghost lists grew to %d entries total, want them bounded regardless of how many unique keys were ever added", b1+b2)
+	}
+}
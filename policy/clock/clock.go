@@ -0,0 +1,83 @@
+// Package clock implements the CLOCK (second-chance) eviction policy, an
+// approximation of LRU that avoids moving list elements on every access.
+package clock
+
+// Policy evicts keys using a circular buffer of reference bits: Evict sweeps
+// the hand forward, clearing reference bits, until it finds a key whose bit
+// was already clear.
+type Policy struct {
+	capacity int
+	keys     []string
+	referred []bool
+	index    map[string]int
+	hand     int
+}
+
+// New creates a Clock Policy for the given capacity.
+func New(capacity int) *Policy {
+	return &Policy{
+		capacity: capacity,
+		index:    make(map[string]int),
+	}
+}
+
+// Add inserts key into the clock with its reference bit set.
+func (p *Policy) Add(key string) {
+	if i, ok := p.index[key]; ok {
+		p.referred[i] = true
+		return
+	}
+	p.keys = append(p.keys, key)
+	p.referred = append(p.referred, true)
+	p.index[key] = len(p.keys) - 1
+}
+
+// Get sets key's reference bit, giving it a second chance.
+func (p *Policy) Get(key string) {
+	if i, ok := p.index[key]; ok {
+		p.referred[i] = true
+	}
+}
+
+// Evict sweeps the clock hand, clearing reference bits, until it finds a key
+// with a clear bit to evict.
+func (p *Policy) Evict() (string, bool) {
+	for len(p.keys) > 0 {
+		p.hand %= len(p.keys)
+
+		if p.referred[p.hand] {
+			p.referred[p.hand] = false
+			p.hand++
+			continue
+		}
+
+		key := p.keys[p.hand]
+		p.removeAt(p.hand)
+		return key, true
+	}
+	return "", false
+}
+
+// Remove forgets key, wherever it sits in the clock.
+func (p *Policy) Remove(key string) {
+	if i, ok := p.index[key]; ok {
+		p.removeAt(i)
+	}
+}
+
+func (p *Policy) removeAt(i int) {
+	key := p.keys[i]
+	last := len(p.keys) - 1
+
+	p.keys[i] = p.keys[last]
+	p.referred[i] = p.referred[last]
+	p.index[p.keys[i]] = i
+
+	p.keys = p.keys[:last]
+	p.referred = p.referred[:last]
+	delete(p.index, key)
+
+	if p.hand > last {
+		p.hand = 0
+	}
+}
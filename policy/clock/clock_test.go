@@ -0,0 +1,55 @@
+package clock_test
+
+import (
+	"testing"
+
+	"github.com/GuilhermeNogueira/go_cache/policy/clock"
+)
+
+func TestGivesReferencedKeysASecondChance(t *testing.T) {
+	p := clock.New(3)
+
+	p.Add("a")
+	p.Add("b")
+	p.Add("c")
+
+	// First full sweep finds every bit set and clears them all, landing on
+	// the oldest entry once the hand wraps back around.
+	if key, ok := p.Evict(); !ok || key != "a" {
+		t.Fatalf("Evict() = %q, %v, want %q, true", key, ok, "a")
+	}
+
+	p.Add("d")
+	p.Get("b") // b gets a second chance just before the hand reaches it again
+
+	if key, ok := p.Evict(); !ok || key != "c" {
+		t.Fatalf("Evict() = %q, %v, want %q, true", key, ok, "c")
+	}
+	if key, ok := p.Evict(); !ok || key != "d" {
+		t.Fatalf("Evict() = %q, %v, want %q, true", key, ok, "d")
+	}
+}
+
+func TestRemove(t *testing.T) {
+	p := clock.New(2)
+	p.Add("a")
+	p.Add("b")
+
+	p.Remove("a")
+
+	key, ok := p.Evict()
+	if !ok || key != "b" {
+		t.Fatalf("Evict() = %q, %v, want %q, true", key, ok, "b")
+	}
+	if _, ok := p.Evict(); ok {
+		t.Fatalf("Evict() ok = true on empty policy, want false")
+	}
+}
+
+func TestEvictOnEmptyPolicy(t *testing.T) {
+	p := clock.New(2)
+
+	if _, ok := p.Evict(); ok {
+		t.Fatalf("Evict() ok = true on empty policy, want false")
+	}
+}
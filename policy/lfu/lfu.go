@@ -0,0 +1,114 @@
+// Package lfu implements a least-frequently-used eviction policy, keeping a
+// per-frequency bucket of keys so that Get is O(1). Evict is O(1) too, except
+// when it drains the lowest-frequency bucket: it then scans every tracked key
+// to find the new minimum frequency.
+package lfu
+
+import "container/list"
+
+// Policy evicts the least frequently used key once the cache is over
+// capacity. Ties within a frequency are broken in least-recently-used order.
+type Policy struct {
+	capacity  int
+	minFreq   int
+	freqOf    map[string]int
+	elements  map[string]*list.Element
+	freqLists map[int]*list.List
+}
+
+// New creates an LFU Policy for the given capacity.
+func New(capacity int) *Policy {
+	return &Policy{
+		capacity:  capacity,
+		freqOf:    make(map[string]int),
+		elements:  make(map[string]*list.Element),
+		freqLists: make(map[int]*list.List),
+	}
+}
+
+func (p *Policy) listFor(freq int) *list.List {
+	l, ok := p.freqLists[freq]
+	if !ok {
+		l = list.New()
+		p.freqLists[freq] = l
+	}
+	return l
+}
+
+// Add registers key with an initial frequency of 1.
+func (p *Policy) Add(key string) {
+	if _, ok := p.freqOf[key]; ok {
+		p.touch(key)
+		return
+	}
+	p.freqOf[key] = 1
+	p.elements[key] = p.listFor(1).PushFront(key)
+	p.minFreq = 1
+}
+
+// Get bumps key's frequency by one.
+func (p *Policy) Get(key string) {
+	if _, ok := p.freqOf[key]; ok {
+		p.touch(key)
+	}
+}
+
+func (p *Policy) touch(key string) {
+	freq := p.freqOf[key]
+	p.listFor(freq).Remove(p.elements[key])
+	if freq == p.minFreq && p.listFor(freq).Len() == 0 {
+		p.minFreq++
+	}
+
+	freq++
+	p.freqOf[key] = freq
+	p.elements[key] = p.listFor(freq).PushFront(key)
+}
+
+// Evict removes and returns the least frequently used key.
+func (p *Policy) Evict() (string, bool) {
+	l, ok := p.freqLists[p.minFreq]
+	if !ok || l.Len() == 0 {
+		return "", false
+	}
+
+	elem := l.Back()
+	l.Remove(elem)
+	key := elem.Value.(string)
+	delete(p.elements, key)
+	delete(p.freqOf, key)
+
+	if l.Len() == 0 {
+		p.recomputeMinFreq()
+	}
+
+	return key, true
+}
+
+// recomputeMinFreq re-derives minFreq after the bucket it pointed at has
+// drained, since touch only ever moves it forward, never back down.
+func (p *Policy) recomputeMinFreq() {
+	min := 0
+	for _, freq := range p.freqOf {
+		if min == 0 || freq < min {
+			min = freq
+		}
+	}
+	p.minFreq = min
+}
+
+// Remove forgets key, wherever it sits in the frequency lists.
+func (p *Policy) Remove(key string) {
+	freq, ok := p.freqOf[key]
+	if !ok {
+		return
+	}
+	l := p.listFor(freq)
+	l.Remove(p.elements[key])
+	delete(p.elements, key)
+	delete(p.freqOf, key)
+
+	if l.Len() == 0 && freq == p.minFreq {
+		p.recomputeMinFreq()
+	}
+}
@@ -0,0 +1,78 @@
+package lfu_test
+
+import (
+	"testing"
+
+	"github.com/GuilhermeNogueira/go_cache/policy/lfu"
+)
+
+func TestEvictsLeastFrequentlyUsed(t *testing.T) {
+	p := lfu.New(3)
+
+	p.Add("a")
+	p.Add("b")
+	p.Add("c")
+	p.Get("a")
+	p.Get("a")
+	p.Get("b")
+	// frequencies: a=3, b=2, c=1
+
+	key, ok := p.Evict()
+	if !ok || key != "c" {
+		t.Fatalf("Evict() = %q, %v, want %q, true", key, ok, "c")
+	}
+
+	key, ok = p.Evict()
+	if !ok || key != "b" {
+		t.Fatalf("Evict() = %q, %v, want %q, true", key, ok, "b")
+	}
+}
+
+func TestTiesBrokenByRecency(t *testing.T) {
+	p := lfu.New(2)
+
+	p.Add("a")
+	p.Add("b")
+	// both at frequency 1, b is the most recently added
+
+	key, ok := p.Evict()
+	if !ok || key != "a" {
+		t.Fatalf("Evict() = %q, %v, want %q, true", key, ok, "a")
+	}
+}
+
+func TestRemove(t *testing.T) {
+	p := lfu.New(2)
+	p.Add("a")
+	p.Add("b")
+
+	p.Remove("a")
+
+	key, ok := p.Evict()
+	if !ok || key != "b" {
+		t.Fatalf("Evict() = %q, %v, want %q, true", key, ok, "b")
+	}
+}
+
+func TestRemoveSoleOccupantOfMinFreqBucket(t *testing.T) {
+	p := lfu.New(2)
+	p.Add("a")
+	p.Add("b")
+	p.Get("a")
+	// a is at freq 2, b is the sole occupant of the freq-1 (min) bucket
+
+	p.Remove("b")
+
+	key, ok := p.Evict()
+	if !ok || key != "a" {
+		t.Fatalf("Evict() = %q, %v, want %q, true", key, ok, "a")
+	}
+}
+
+func TestEvictOnEmptyPolicy(t *testing.T) {
+	p := lfu.New(2)
+
+	if _, ok := p.Evict(); ok {
+		t.Fatalf("Evict() ok = true on empty policy, want false")
+	}
+}
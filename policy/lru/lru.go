@@ -0,0 +1,57 @@
+// Package lru implements a least-recently-used eviction policy backed by a
+// doubly-linked list, in the same spirit as generics-cache and ccache.
+package lru
+
+import "container/list"
+
+// Policy evicts the least recently used key once the cache is over capacity.
+type Policy struct {
+	capacity int
+	list     *list.List
+	elements map[string]*list.Element
+}
+
+// New creates an LRU Policy for the given capacity.
+func New(capacity int) *Policy {
+	return &Policy{
+		capacity: capacity,
+		list:     list.New(),
+		elements: make(map[string]*list.Element),
+	}
+}
+
+// Add registers key as the most recently used entry.
+func (p *Policy) Add(key string) {
+	if elem, ok := p.elements[key]; ok {
+		p.list.MoveToFront(elem)
+		return
+	}
+	p.elements[key] = p.list.PushFront(key)
+}
+
+// Get moves key to the front, marking it as most recently used.
+func (p *Policy) Get(key string) {
+	if elem, ok := p.elements[key]; ok {
+		p.list.MoveToFront(elem)
+	}
+}
+
+// Evict removes and returns the least recently used key.
+func (p *Policy) Evict() (string, bool) {
+	elem := p.list.Back()
+	if elem == nil {
+		return "", false
+	}
+	p.list.Remove(elem)
+	key := elem.Value.(string)
+	delete(p.elements, key)
+	return key, true
+}
+
+// Remove forgets key, wherever it sits in the list.
+func (p *Policy) Remove(key string) {
+	if elem, ok := p.elements[key]; ok {
+		p.list.Remove(elem)
+		delete(p.elements, key)
+	}
+}
@@ -0,0 +1,57 @@
+package lru_test
+
+import (
+	"testing"
+
+	"github.com/GuilhermeNogueira/go_cache/policy/lru"
+)
+
+func TestEvictsLeastRecentlyUsed(t *testing.T) {
+	p := lru.New(2)
+
+	p.Add("a")
+	p.Add("b")
+	p.Get("a") // a is now more recently used than b
+
+	key, ok := p.Evict()
+	if !ok || key != "b" {
+		t.Fatalf("Evict() = %q, %v, want %q, true", key, ok, "b")
+	}
+}
+
+func TestAddOnExistingKeyRefreshesRecency(t *testing.T) {
+	p := lru.New(2)
+
+	p.Add("a")
+	p.Add("b")
+	p.Add("a") // re-adding a should behave like an access
+
+	key, ok := p.Evict()
+	if !ok || key != "b" {
+		t.Fatalf("Evict() = %q, %v, want %q, true", key, ok, "b")
+	}
+}
+
+func TestRemove(t *testing.T) {
+	p := lru.New(2)
+	p.Add("a")
+	p.Add("b")
+
+	p.Remove("a")
+
+	key, ok := p.Evict()
+	if !ok || key != "b" {
+		t.Fatalf("Evict() = %q, %v, want %q, true", key, ok, "b")
+	}
+	if _, ok := p.Evict(); ok {
+		t.Fatalf("Evict() ok = true on empty policy, want false")
+	}
+}
+
+func TestEvictOnEmptyPolicy(t *testing.T) {
+	p := lru.New(2)
+
+	if _, ok := p.Evict(); ok {
+		t.Fatalf("Evict() ok = true on empty policy, want false")
+	}
+}
@@ -0,0 +1,18 @@
+// Package policy defines the contract eviction policies must satisfy so that
+// TransparentCache can stay agnostic of which replacement algorithm is in use.
+package policy
+
+// Policy tracks which keys are candidates for eviction once a cache grows
+// past its configured capacity. Implementations are not expected to be
+// safe for concurrent use; callers are responsible for synchronization.
+type Policy interface {
+	// Add registers a newly inserted key with the policy.
+	Add(key string)
+	// Get records an access to an existing key (a cache hit).
+	Get(key string)
+	// Evict picks a key to remove according to the policy and forgets it.
+	// ok is false when the policy has no key left to evict.
+	Evict() (key string, ok bool)
+	// Remove forgets a key, e.g. because it expired or was deleted directly.
+	Remove(key string)
+}
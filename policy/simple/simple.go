@@ -0,0 +1,26 @@
+// Package simple provides a no-op eviction policy. It never offers a key to
+// evict, so a cache using it keeps every entry even past its capacity.
+package simple
+
+// Policy is a no-op implementation of policy.Policy: it never evicts
+// anything on its own.
+type Policy struct{}
+
+// New creates a no-op Policy.
+func New() *Policy {
+	return &Policy{}
+}
+
+// Add does nothing.
+func (p *Policy) Add(key string) {}
+
+// Get does nothing.
+func (p *Policy) Get(key string) {}
+
+// Evict never has anything to evict.
+func (p *Policy) Evict() (string, bool) {
+	return "", false
+}
+
+// Remove does nothing.
+func (p *Policy) Remove(key string) {}
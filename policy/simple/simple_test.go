@@ -0,0 +1,19 @@
+package simple_test
+
+import (
+	"testing"
+
+	"github.com/GuilhermeNogueira/go_cache/policy/simple"
+)
+
+func TestNeverEvicts(t *testing.T) {
+	p := simple.New()
+
+	p.Add("a")
+	p.Get("a")
+	p.Remove("b") // no-op, must not panic
+
+	if _, ok := p.Evict(); ok {
+		t.Fatalf("Evict() ok = true, want false")
+	}
+}
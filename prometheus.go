@@ -0,0 +1,52 @@
+package go_cache
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// prometheusMetrics holds the collectors registered via WithPrometheus,
+// updated alongside the cache's own Stats on every relevant code path.
+type prometheusMetrics struct {
+	hits            prometheus.Counter
+	misses          prometheus.Counter
+	errors          prometheus.Counter
+	evictions       prometheus.Counter
+	upstreamLatency prometheus.Histogram
+}
+
+// WithPrometheus registers cache_hits_total, cache_misses_total,
+// cache_errors_total and cache_evictions_total counters, plus a histogram
+// for upstream PriceService latency, with registerer under namespace.
+func WithPrometheus(registerer prometheus.Registerer, namespace string) Option {
+	return func(c *TransparentCache) {
+		m := &prometheusMetrics{
+			hits: prometheus.NewCounter(prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "cache_hits_total",
+				Help:      "Total number of cache hits.",
+			}),
+			misses: prometheus.NewCounter(prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "cache_misses_total",
+				Help:      "Total number of cache misses.",
+			}),
+			errors: prometheus.NewCounter(prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "cache_errors_total",
+				Help:      "Total number of upstream PriceService errors.",
+			}),
+			evictions: prometheus.NewCounter(prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "cache_evictions_total",
+				Help:      "Total number of entries evicted by the cache's policy or expired and reaped by the janitor.",
+			}),
+			upstreamLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "cache_upstream_latency_seconds",
+				Help:      "Latency of calls to the upstream PriceService.",
+			}),
+		}
+
+		registerer.MustRegister(m.hits, m.misses, m.errors, m.evictions, m.upstreamLatency)
+
+		c.prometheus = m
+	}
+}
@@ -0,0 +1,49 @@
+package go_cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestWithPrometheusRegistersAndUpdatesCounters(t *testing.T) {
+	svc := &countingPriceService{}
+	registry := prometheus.NewRegistry()
+	c := NewTransparentCache(svc, time.Minute, WithPrometheus(registry, "testcache"))
+
+	if _, err := c.GetPriceFor("a"); err != nil {
+		t.Fatalf("GetPriceFor() error = %v", err)
+	}
+	if _, err := c.GetPriceFor("a"); err != nil {
+		t.Fatalf("GetPriceFor() error = %v", err)
+	}
+
+	if got := testutil.ToFloat64(c.prometheus.misses); got != 1 {
+		t.Fatalf("cache_misses_total = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(c.prometheus.hits); got != 1 {
+		t.Fatalf("cache_hits_total = %v, want 1", got)
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("registry.Gather() error = %v", err)
+	}
+	names := make(map[string]bool)
+	for _, f := range families {
+		names[f.GetName()] = true
+	}
+	for _, want := range []string{
+		"testcache_cache_hits_total",
+		"testcache_cache_misses_total",
+		"testcache_cache_errors_total",
+		"testcache_cache_evictions_total",
+		"testcache_cache_upstream_latency_seconds",
+	} {
+		if !names[want] {
+			t.Fatalf("registry is missing metric %q, got %v", want, names)
+		}
+	}
+}
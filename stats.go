@@ -0,0 +1,67 @@
+package go_cache
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats is a point-in-time snapshot of a TransparentCache's counters.
+type Stats struct {
+	Hits              uint64
+	Misses            uint64
+	Errors            uint64
+	Evictions         uint64
+	InFlightCoalesced uint64
+	UpstreamLatency   time.Duration
+}
+
+// cacheStats holds the live, atomically-updated counters a TransparentCache
+// is built from. All fields are accessed only through sync/atomic.
+type cacheStats struct {
+	hits                 uint64
+	misses               uint64
+	errors               uint64
+	evictions            uint64
+	inFlightCoalesced    uint64
+	upstreamLatencyNanos int64
+}
+
+func (s *cacheStats) recordHit() {
+	atomic.AddUint64(&s.hits, 1)
+}
+
+func (s *cacheStats) recordMiss() {
+	atomic.AddUint64(&s.misses, 1)
+}
+
+func (s *cacheStats) recordError() {
+	atomic.AddUint64(&s.errors, 1)
+}
+
+func (s *cacheStats) recordEvictions(n int) {
+	atomic.AddUint64(&s.evictions, uint64(n))
+}
+
+func (s *cacheStats) recordInFlightCoalesced() {
+	atomic.AddUint64(&s.inFlightCoalesced, 1)
+}
+
+func (s *cacheStats) recordUpstreamLatency(d time.Duration) {
+	atomic.AddInt64(&s.upstreamLatencyNanos, int64(d))
+}
+
+func (s *cacheStats) snapshot() Stats {
+	return Stats{
+		Hits:              atomic.LoadUint64(&s.hits),
+		Misses:            atomic.LoadUint64(&s.misses),
+		Errors:            atomic.LoadUint64(&s.errors),
+		Evictions:         atomic.LoadUint64(&s.evictions),
+		InFlightCoalesced: atomic.LoadUint64(&s.inFlightCoalesced),
+		UpstreamLatency:   time.Duration(atomic.LoadInt64(&s.upstreamLatencyNanos)),
+	}
+}
+
+// Stats returns a snapshot of the cache's hit/miss/error/eviction counters.
+func (c *TransparentCache) Stats() Stats {
+	return c.stats.snapshot()
+}
@@ -0,0 +1,56 @@
+package go_cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GuilhermeNogueira/go_cache/policy/lru"
+)
+
+func TestStatsTracksHitsMissesErrors(t *testing.T) {
+	svc := &countingPriceService{}
+	c := NewTransparentCache(svc, time.Minute)
+
+	if _, err := c.GetPriceFor("a"); err != nil {
+		t.Fatalf("GetPriceFor() error = %v", err)
+	}
+	if _, err := c.GetPriceFor("a"); err != nil {
+		t.Fatalf("GetPriceFor() error = %v", err)
+	}
+
+	failingSvc := &countingPriceService{err: errors.New("boom")}
+	failing := NewTransparentCache(failingSvc, time.Minute)
+	if _, err := failing.GetPriceFor("b"); err == nil {
+		t.Fatal("GetPriceFor() error = nil, want an error")
+	}
+
+	stats := c.Stats()
+	if stats.Hits != 1 {
+		t.Fatalf("Stats().Hits = %d, want 1", stats.Hits)
+	}
+	if stats.Misses != 1 {
+		t.Fatalf("Stats().Misses = %d, want 1", stats.Misses)
+	}
+
+	failingStats := failing.Stats()
+	if failingStats.Errors != 1 {
+		t.Fatalf("Stats().Errors = %d, want 1", failingStats.Errors)
+	}
+}
+
+func TestStatsTracksEvictions(t *testing.T) {
+	svc := &countingPriceService{}
+	c := NewTransparentCache(svc, time.Minute, WithCapacity(1), WithPolicy(lru.New(1)))
+
+	if _, err := c.GetPriceFor("a"); err != nil {
+		t.Fatalf("GetPriceFor() error = %v", err)
+	}
+	if _, err := c.GetPriceFor("b"); err != nil {
+		t.Fatalf("GetPriceFor() error = %v", err)
+	}
+
+	if got := c.Stats().Evictions; got != 1 {
+		t.Fatalf("Stats().Evictions = %d, want 1", got)
+	}
+}
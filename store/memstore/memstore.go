@@ -0,0 +1,65 @@
+// Package memstore provides the default in-memory store.Store
+// implementation, matching TransparentCache's original behavior.
+package memstore
+
+import (
+	"sync"
+
+	"github.com/GuilhermeNogueira/go_cache/store"
+)
+
+// Store is a mutex-protected map[string]store.ItemPriceCache.
+type Store struct {
+	mu    sync.RWMutex
+	items map[string]store.ItemPriceCache
+}
+
+// New creates an empty in-memory Store.
+func New() *Store {
+	return &Store{items: map[string]store.ItemPriceCache{}}
+}
+
+// Get returns the item cached under key, if any.
+func (s *Store) Get(key string) (store.ItemPriceCache, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	item, ok := s.items[key]
+	return item, ok
+}
+
+// Set stores item under key, replacing any existing entry.
+func (s *Store) Set(key string, item store.ItemPriceCache) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.items[key] = item
+}
+
+// Delete removes key, if present.
+func (s *Store) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.items, key)
+}
+
+// Len returns the number of entries currently stored.
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.items)
+}
+
+// Keys returns every key currently stored, in no particular order.
+func (s *Store) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.items))
+	for key := range s.items {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,13 @@
+package memstore_test
+
+import (
+	"testing"
+
+	"github.com/GuilhermeNogueira/go_cache/store"
+	"github.com/GuilhermeNogueira/go_cache/store/memstore"
+	"github.com/GuilhermeNogueira/go_cache/store/storetest"
+)
+
+func TestStore(t *testing.T) {
+	storetest.Run(t, func() store.Store { return memstore.New() })
+}
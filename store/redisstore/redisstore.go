@@ -0,0 +1,85 @@
+// Package redisstore provides a store.Store backed by Redis, letting
+// multiple TransparentCache instances share a price cache the way projects
+// like Souin share a common provider interface over Redis/Badger/Etcd.
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/GuilhermeNogueira/go_cache/store"
+)
+
+// Store is a store.Store backed by Redis. Instead of tracking Expiration
+// itself, it sets a matching Redis TTL on every key and lets Redis expire
+// entries on its own.
+type Store struct {
+	client    *redis.Client
+	keyPrefix string
+}
+
+// New creates a Store that stores every key under keyPrefix in client.
+func New(client *redis.Client, keyPrefix string) *Store {
+	return &Store{client: client, keyPrefix: keyPrefix}
+}
+
+func (s *Store) prefixed(key string) string {
+	return s.keyPrefix + key
+}
+
+// Get returns the item cached under key, if any.
+func (s *Store) Get(key string) (store.ItemPriceCache, bool) {
+	data, err := s.client.Get(context.Background(), s.prefixed(key)).Bytes()
+	if err != nil {
+		return store.ItemPriceCache{}, false
+	}
+
+	var item store.ItemPriceCache
+	if err := json.Unmarshal(data, &item); err != nil {
+		return store.ItemPriceCache{}, false
+	}
+	return item, true
+}
+
+// Set stores item under key with a TTL matching its Expiration, replacing
+// any existing entry. An item whose Expiration has already passed is not
+// stored.
+func (s *Store) Set(key string, item store.ItemPriceCache) {
+	ttl := time.Until(time.Unix(0, item.Expiration))
+	if ttl <= 0 {
+		return
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return
+	}
+
+	s.client.Set(context.Background(), s.prefixed(key), data, ttl)
+}
+
+// Delete removes key, if present.
+func (s *Store) Delete(key string) {
+	s.client.Del(context.Background(), s.prefixed(key))
+}
+
+// Len returns the number of entries currently stored.
+func (s *Store) Len() int {
+	return len(s.Keys())
+}
+
+// Keys returns every key currently stored, in no particular order.
+func (s *Store) Keys() []string {
+	ctx := context.Background()
+
+	var keys []string
+	iter := s.client.Scan(ctx, 0, s.prefixed("*"), 0).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, strings.TrimPrefix(iter.Val(), s.keyPrefix))
+	}
+	return keys
+}
@@ -0,0 +1,42 @@
+package redisstore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/GuilhermeNogueira/go_cache/store"
+	"github.com/GuilhermeNogueira/go_cache/store/redisstore"
+	"github.com/GuilhermeNogueira/go_cache/store/storetest"
+)
+
+func newTestStore(t *testing.T) store.Store {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return redisstore.New(client, "go_cache:test:")
+}
+
+func TestStore(t *testing.T) {
+	storetest.Run(t, func() store.Store { return newTestStore(t) })
+}
+
+func TestStoreRespectsExpiration(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	s := redisstore.New(client, "go_cache:test:")
+	s.Set("foo", store.ItemPriceCache{Price: 1, Expiration: time.Now().Add(time.Second).UnixNano()})
+
+	mr.FastForward(2 * time.Second)
+
+	if _, ok := s.Get("foo"); ok {
+		t.Fatalf("Get(%q) = _, true after TTL elapsed, want false", "foo")
+	}
+}
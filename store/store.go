@@ -0,0 +1,32 @@
+// Package store defines the storage backend contract TransparentCache reads
+// and writes prices through, so that the same cache semantics can run
+// against either an in-memory map or a shared backend like Redis.
+package store
+
+import "time"
+
+// ItemPriceCache wraps a cached item with its price and expiration.
+type ItemPriceCache struct {
+	Price      float64
+	Expiration int64
+}
+
+// IsExpired returns true when the item is older than its Expiration.
+func (item ItemPriceCache) IsExpired() bool {
+	return time.Now().UnixNano() > item.Expiration
+}
+
+// Store is a storage backend for a TransparentCache. Implementations must be
+// safe for concurrent use.
+type Store interface {
+	// Get returns the item cached under key, if any.
+	Get(key string) (ItemPriceCache, bool)
+	// Set stores item under key, replacing any existing entry.
+	Set(key string, item ItemPriceCache)
+	// Delete removes key, if present.
+	Delete(key string)
+	// Len returns the number of entries currently stored.
+	Len() int
+	// Keys returns every key currently stored, in no particular order.
+	Keys() []string
+}
@@ -0,0 +1,75 @@
+// Package storetest is a backend-agnostic compliance suite that any new
+// store.Store implementation should pass.
+package storetest
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/GuilhermeNogueira/go_cache/store"
+)
+
+// Run exercises the store.Store contract against a fresh instance produced
+// by newStore, which must return an empty store each time it's called.
+func Run(t *testing.T, newStore func() store.Store) {
+	t.Helper()
+
+	t.Run("SetAndGet", func(t *testing.T) {
+		s := newStore()
+		item := store.ItemPriceCache{Price: 12.5, Expiration: time.Now().Add(time.Minute).UnixNano()}
+
+		s.Set("foo", item)
+
+		got, ok := s.Get("foo")
+		if !ok {
+			t.Fatalf("Get(%q) = _, false, want true", "foo")
+		}
+		if got != item {
+			t.Fatalf("Get(%q) = %+v, want %+v", "foo", got, item)
+		}
+	})
+
+	t.Run("GetMissingKey", func(t *testing.T) {
+		s := newStore()
+
+		if _, ok := s.Get("missing"); ok {
+			t.Fatalf("Get(%q) = _, true, want false", "missing")
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		s := newStore()
+		s.Set("foo", store.ItemPriceCache{Price: 1, Expiration: time.Now().Add(time.Minute).UnixNano()})
+
+		s.Delete("foo")
+
+		if _, ok := s.Get("foo"); ok {
+			t.Fatalf("Get(%q) = _, true after Delete, want false", "foo")
+		}
+	})
+
+	t.Run("LenAndKeys", func(t *testing.T) {
+		s := newStore()
+		want := []string{"a", "b", "c"}
+		for _, key := range want {
+			s.Set(key, store.ItemPriceCache{Price: 1, Expiration: time.Now().Add(time.Minute).UnixNano()})
+		}
+
+		if got := s.Len(); got != len(want) {
+			t.Fatalf("Len() = %d, want %d", got, len(want))
+		}
+
+		got := s.Keys()
+		sort.Strings(got)
+		sort.Strings(want)
+		if len(got) != len(want) {
+			t.Fatalf("Keys() = %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("Keys() = %v, want %v", got, want)
+			}
+		}
+	})
+}